@@ -0,0 +1,284 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package button implements a widget that displays a button that can be
+// activated with a keyboard key or a mouse click.
+package button
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Button displays a text label that can be "pressed" either by a
+// configurable keyboard key or by clicking it with the mouse.
+//
+// Implements the widgetapi.Widget interface.
+type Button struct {
+	// text is the label displayed on the button, i.e. the chunks'
+	// concatenated text. Used for group member comparisons and for the
+	// bracketed selected-state label.
+	text string
+
+	// chunks are the styled runs of text that make up the label.
+	chunks []*TextChunk
+
+	// callback is called when the button is pressed.
+	callback func() error
+
+	// opts are the options provided at construction time.
+	opts *options
+
+	// area is the button's area on the canvas as of the last Draw call,
+	// used to tell whether a globally-delivered mouse event is within the
+	// button so hover can be cleared again once the cursor leaves.
+	area image.Rectangle
+
+	// hovered is true while the mouse cursor is over the button.
+	hovered bool
+	// mousePressed is true while the mouse button is held down over the
+	// button.
+	mousePressed bool
+	// keyPressedUntil is the time until which a keyboard-triggered press
+	// still shows the pressed colors.
+	keyPressedUntil time.Time
+	// disabled tracks the runtime disabled state, seeded from the
+	// Disabled option and mutable via SetDisabled.
+	disabled bool
+
+	// mu protects the button.
+	mu sync.Mutex
+}
+
+// New returns a new Button that displays the provided text and calls the
+// provided callback when pressed.
+func New(text string, callback func() error, opts ...Option) (*Button, error) {
+	if err := validateText(text); err != nil {
+		return nil, err
+	}
+	return newButton([]*TextChunk{NewChunk(text)}, callback, opts...)
+}
+
+// newButton is the shared constructor used by New and NewFromChunks.
+func newButton(chunks []*TextChunk, callback func() error, opts ...Option) (*Button, error) {
+	if callback == nil {
+		return nil, fmt.Errorf("the callback function must not be nil")
+	}
+
+	o := newOptions(chunksWidth(chunks))
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	b := &Button{
+		text:     chunksText(chunks),
+		chunks:   chunks,
+		callback: callback,
+		opts:     o,
+		disabled: o.disabled,
+	}
+	if o.group != nil {
+		o.group.register(b)
+	}
+	return b, nil
+}
+
+// validateText validates the provided button label.
+func validateText(text string) error {
+	for _, r := range text {
+		if r == '\n' || r == '\r' {
+			return fmt.Errorf("invalid text %q, button labels cannot contain newlines", text)
+		}
+		if r < ' ' {
+			return fmt.Errorf("invalid text %q, button labels cannot contain control characters", text)
+		}
+	}
+	return nil
+}
+
+// Draw draws the Button widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (b *Button) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.area = cvs.Area()
+
+	fillColor := b.opts.fillColor
+	textColor := b.opts.textColor
+	shadowColor := b.opts.shadowColor
+	selected := b.opts.group != nil && b.opts.group.isSelected(b)
+	if selected {
+		fillColor = b.opts.selectedFillColor
+		textColor = b.opts.selectedTextColor
+	}
+
+	pressed := b.mousePressed || time.Now().Before(b.keyPressedUntil)
+	switch {
+	case b.disabled:
+		fillColor = b.opts.disabledFillColor
+		textColor = b.opts.disabledTextColor
+	case pressed:
+		fillColor = b.opts.pressedFillColor
+		textColor = b.opts.pressedTextColor
+	case b.hovered:
+		fillColor = b.opts.hoverFillColor
+		textColor = b.opts.hoverTextColor
+		shadowColor = b.opts.hoverShadowColor
+	}
+
+	area := cvs.Area()
+	textAreaHeight := b.opts.height - 1 // Bottom row is reserved for the shadow.
+	textArea := image.Rect(area.Min.X, area.Min.Y, area.Max.X, area.Min.Y+textAreaHeight)
+	if err := draw.Rectangle(cvs, textArea, draw.RectCellOpts(cell.BgColor(fillColor))); err != nil {
+		return err
+	}
+
+	shadowArea := image.Rect(area.Min.X+1, area.Min.Y+textAreaHeight, area.Max.X, area.Max.Y)
+	if err := draw.Rectangle(cvs, shadowArea, draw.RectCellOpts(cell.BgColor(shadowColor))); err != nil {
+		return err
+	}
+
+	if selected {
+		// The selected state of a group member always renders as a plain,
+		// bracketed label, regardless of any chunk styling.
+		start := image.Point{area.Min.X, area.Min.Y + textAreaHeight/2}
+		return draw.Text(
+			cvs, "["+b.text+"]", start,
+			draw.TextMaxX(area.Max.X),
+			draw.TextCellOpts(cell.FgColor(textColor), cell.BgColor(fillColor)),
+			draw.TextAlignHorizontal(align.HorizontalCenter),
+		)
+	}
+	return drawChunks(cvs, b.chunks, textArea, fillColor, textColor)
+}
+
+// press marks the button as selected in its ButtonGroup, if any, and then
+// invokes the callback. A disabled button does nothing.
+func (b *Button) press() error {
+	if b.IsDisabled() {
+		return nil
+	}
+	if b.opts.group != nil {
+		b.opts.group.selectButton(b)
+	}
+	return b.callback()
+}
+
+// Keyboard processes keyboard events, activating the button when the
+// configured Key is pressed. When the button is a member of a ButtonGroup
+// and focused, the left and right arrow keys move the press to the
+// adjacent group member, turning the group into a horizontal radio bar.
+// Implements widgetapi.Widget.Keyboard.
+func (b *Button) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	b.mu.Lock()
+	group := b.opts.group
+	key := k.Key
+	focused := meta.Focused
+	if key == b.opts.key {
+		b.keyPressedUntil = time.Now().Add(b.opts.pressedDuration)
+	}
+	b.mu.Unlock()
+
+	switch {
+	case key == b.opts.key:
+		return b.press()
+	case group != nil && focused && key == keyboard.KeyArrowLeft:
+		if adj := group.adjacent(b, -1); adj != nil {
+			return adj.press()
+		}
+	case group != nil && focused && key == keyboard.KeyArrowRight:
+		if adj := group.adjacent(b, 1); adj != nil {
+			return adj.press()
+		}
+	}
+	return nil
+}
+
+// Mouse processes mouse events. Button requests MouseScopeGlobal so it
+// keeps receiving events after the cursor leaves its area, which is what
+// lets it clear the hovered state again; within its own area it tracks the
+// MouseButtonLeft / MouseButtonRelease transition to drive the pressed
+// state and to activate the button on a completed click.
+// Implements widgetapi.Widget.Mouse.
+func (b *Button) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	b.mu.Lock()
+	within := m.Position.In(b.area)
+	b.hovered = within
+	if !within {
+		b.mousePressed = false
+		b.mu.Unlock()
+		return nil
+	}
+
+	var click bool
+	switch m.Button {
+	case mouse.ButtonLeft:
+		b.mousePressed = true
+	case mouse.ButtonRelease:
+		click = b.mousePressed
+		b.mousePressed = false
+	}
+	b.mu.Unlock()
+
+	if click {
+		return b.press()
+	}
+	return nil
+}
+
+// SetDisabled sets the disabled state of the button at runtime. A disabled
+// button ignores keyboard and mouse activation and renders with its
+// DisabledFillColor and DisabledTextColor.
+func (b *Button) SetDisabled(disabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.disabled = disabled
+}
+
+// IsDisabled reports whether the button is currently disabled.
+func (b *Button) IsDisabled() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.disabled
+}
+
+// Options implements widgetapi.Widget.Options.
+func (b *Button) Options() widgetapi.Options {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return widgetapi.Options{
+		MinimumSize:  image.Point{b.opts.width, b.opts.height},
+		MaximumSize:  image.Point{b.opts.width, b.opts.height},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeGlobal,
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestButtonPress(t *testing.T) {
+	pressed := 0
+	b, err := New("label", func() error {
+		pressed++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("New() => unexpected error: %v", err)
+	}
+
+	if err := b.press(); err != nil {
+		t.Fatalf("press() => unexpected error: %v", err)
+	}
+	if pressed != 1 {
+		t.Errorf("press() invoked the callback %d times, want 1", pressed)
+	}
+}
+
+func TestButtonPressDisabled(t *testing.T) {
+	pressed := 0
+	b, err := New("label", func() error {
+		pressed++
+		return nil
+	}, Disabled(true))
+	if err != nil {
+		t.Fatalf("New() => unexpected error: %v", err)
+	}
+
+	if !b.IsDisabled() {
+		t.Fatal("IsDisabled() => false, want true per the Disabled(true) option")
+	}
+	if err := b.press(); err != nil {
+		t.Fatalf("press() => unexpected error: %v", err)
+	}
+	if pressed != 0 {
+		t.Errorf("press() invoked the callback %d times on a disabled button, want 0", pressed)
+	}
+
+	b.SetDisabled(false)
+	if err := b.press(); err != nil {
+		t.Fatalf("press() => unexpected error: %v", err)
+	}
+	if pressed != 1 {
+		t.Errorf("press() invoked the callback %d times after SetDisabled(false), want 1", pressed)
+	}
+}
+
+func TestButtonMouseHoverAndClick(t *testing.T) {
+	pressed := 0
+	b, err := New("label", func() error {
+		pressed++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("New() => unexpected error: %v", err)
+	}
+	b.area = image.Rect(0, 0, 10, 2)
+	meta := &widgetapi.EventMeta{}
+
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{1, 1}}, meta); err != nil {
+		t.Fatalf("Mouse() (move within area) => unexpected error: %v", err)
+	}
+	if !b.hovered {
+		t.Error("hovered => false after a mouse event within the button's area, want true")
+	}
+
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft}, meta); err != nil {
+		t.Fatalf("Mouse() (press) => unexpected error: %v", err)
+	}
+	if !b.mousePressed {
+		t.Error("mousePressed => false after ButtonLeft within the button's area, want true")
+	}
+
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonRelease}, meta); err != nil {
+		t.Fatalf("Mouse() (release) => unexpected error: %v", err)
+	}
+	if pressed != 1 {
+		t.Errorf("press/release cycle within the button's area invoked the callback %d times, want 1", pressed)
+	}
+	if b.mousePressed {
+		t.Error("mousePressed => true after ButtonRelease, want false")
+	}
+
+	// Once the cursor moves outside the button's area, hovered (and any
+	// in-progress press) must clear, since MouseScopeGlobal means the
+	// widget keeps receiving events there's no other way to detect this.
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{20, 20}}, meta); err != nil {
+		t.Fatalf("Mouse() (move outside area) => unexpected error: %v", err)
+	}
+	if b.hovered {
+		t.Error("hovered => true after the cursor left the button's area, want false")
+	}
+}
+
+func TestButtonMouseLeaveMidPressDoesNotPress(t *testing.T) {
+	pressed := 0
+	b, err := New("label", func() error {
+		pressed++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("New() => unexpected error: %v", err)
+	}
+	b.area = image.Rect(0, 0, 10, 2)
+	meta := &widgetapi.EventMeta{}
+
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft}, meta); err != nil {
+		t.Fatalf("Mouse() (press) => unexpected error: %v", err)
+	}
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{20, 20}}, meta); err != nil {
+		t.Fatalf("Mouse() (move outside area) => unexpected error: %v", err)
+	}
+	if err := b.Mouse(&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonRelease}, meta); err != nil {
+		t.Fatalf("Mouse() (release) => unexpected error: %v", err)
+	}
+	if pressed != 0 {
+		t.Errorf("press() invoked the callback %d times after the cursor left mid-press, want 0", pressed)
+	}
+}
+
+func TestButtonKeyboardPress(t *testing.T) {
+	pressed := 0
+	b, err := New("label", func() error {
+		pressed++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("New() => unexpected error: %v", err)
+	}
+
+	if err := b.Keyboard(&terminalapi.Keyboard{Key: b.opts.key}, &widgetapi.EventMeta{Focused: true}); err != nil {
+		t.Fatalf("Keyboard() => unexpected error: %v", err)
+	}
+	if pressed != 1 {
+		t.Errorf("Keyboard() with the configured Key invoked the callback %d times, want 1", pressed)
+	}
+}
+
+func TestButtonGroupMemberSelectedOnPress(t *testing.T) {
+	g := NewButtonGroup()
+	a := newTestGroupMember(t, g, "A")
+	b := newTestGroupMember(t, g, "B")
+
+	if err := a.press(); err != nil {
+		t.Fatalf("a.press() => unexpected error: %v", err)
+	}
+	if !g.isSelected(a) {
+		t.Error("isSelected(a) => false after a.press(), want true")
+	}
+
+	if err := b.press(); err != nil {
+		t.Fatalf("b.press() => unexpected error: %v", err)
+	}
+	if g.isSelected(a) || !g.isSelected(b) {
+		t.Errorf("after b.press(), isSelected(a) = %v, isSelected(b) = %v, want false, true", g.isSelected(a), g.isSelected(b))
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+// chunk.go contains the TextChunk type and the NewFromChunks constructor,
+// mirroring the text widget's chunk API so a button label can be made up
+// of multiple independently styled runs of text.
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+)
+
+// TextChunk is a run of text with its own cell styling. A Button label can
+// be built out of multiple chunks, each with independent foreground and
+// background colors and attributes (e.g. bold or underline).
+type TextChunk struct {
+	text string
+	opts *writeOptions
+}
+
+// NewChunk returns a new TextChunk that displays text with the provided
+// cell options. Chunks that don't specify their own foreground or
+// background color fall back to the button's FillColor and TextColor.
+func NewChunk(text string, wOpts ...WriteOption) *TextChunk {
+	return &TextChunk{
+		text: text,
+		opts: newWriteOptions(wOpts...),
+	}
+}
+
+// NewFromChunks returns a new Button whose label is the concatenation of
+// the provided TextChunks, each rendered with its own cell styling on top
+// of the button's FillColor and TextColor.
+func NewFromChunks(chunks []*TextChunk, callback func() error, opts ...Option) (*Button, error) {
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("the chunks argument must not be empty")
+	}
+	for _, c := range chunks {
+		if err := validateText(c.text); err != nil {
+			return nil, err
+		}
+	}
+	return newButton(chunks, callback, opts...)
+}
+
+// chunksWidth returns the total visible width of the chunks' text once
+// concatenated.
+func chunksWidth(chunks []*TextChunk) int {
+	width := 0
+	for _, c := range chunks {
+		width += runewidth.StringWidth(c.text)
+	}
+	return width
+}
+
+// chunksText returns the chunks' text concatenated into a single string.
+func chunksText(chunks []*TextChunk) string {
+	text := ""
+	for _, c := range chunks {
+		text += c.text
+	}
+	return text
+}
+
+// drawChunks draws the chunks horizontally centered within area, one after
+// another, defaulting each chunk's foreground and background color to
+// textColor and fillColor when the chunk didn't provide its own.
+func drawChunks(cvs *canvas.Canvas, chunks []*TextChunk, area image.Rectangle, fillColor, textColor cell.Color) error {
+	x := area.Min.X + (area.Dx()-chunksWidth(chunks))/2
+	y := area.Min.Y + area.Dy()/2
+
+	for _, c := range chunks {
+		wo := &writeOptions{cellOpts: append([]cell.Option(nil), c.opts.cellOpts...)}
+		wo.setDefaultFgColor(textColor)
+		cellOpts := append([]cell.Option{cell.BgColor(fillColor)}, wo.cellOpts...)
+
+		if err := draw.Text(
+			cvs, c.text, image.Point{x, y},
+			draw.TextMaxX(area.Max.X),
+			draw.TextCellOpts(cellOpts...),
+		); err != nil {
+			return err
+		}
+		x += runewidth.StringWidth(c.text)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+import "testing"
+
+func TestChunksWidthAndText(t *testing.T) {
+	chunks := []*TextChunk{NewChunk("foo"), NewChunk("bar")}
+
+	if got, want := chunksWidth(chunks), 6; got != want {
+		t.Errorf("chunksWidth(...) => %d, want %d", got, want)
+	}
+	if got, want := chunksText(chunks), "foobar"; got != want {
+		t.Errorf("chunksText(...) => %q, want %q", got, want)
+	}
+}
+
+func TestNewFromChunks(t *testing.T) {
+	tests := []struct {
+		desc    string
+		chunks  []*TextChunk
+		wantErr bool
+	}{
+		{
+			desc:    "empty chunks is rejected",
+			chunks:  nil,
+			wantErr: true,
+		},
+		{
+			desc:    "a chunk with an invalid label is rejected",
+			chunks:  []*TextChunk{NewChunk("foo\nbar")},
+			wantErr: true,
+		},
+		{
+			desc:   "valid chunks succeed",
+			chunks: []*TextChunk{NewChunk("foo"), NewChunk("bar")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := NewFromChunks(tc.chunks, func() error { return nil })
+			gotErr := err != nil
+			if gotErr != tc.wantErr {
+				t.Fatalf("NewFromChunks(%v) => error %v, wantErr %v", tc.chunks, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got, want := b.text, "foobar"; got != want {
+				t.Errorf("resulting Button.text => %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+// dropdown.go contains a DropDown widget, a button that opens a scrollable
+// overlay list of options when activated.
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// DropDown displays a button that, once activated, opens a scrollable
+// overlay list of options directly beneath (or, with the OpenUpward option,
+// above) itself. Selecting an option updates the button's label and invokes
+// the configured callback.
+//
+// Implements the widgetapi.Widget interface.
+type DropDown struct {
+	// opts are the selectable option labels, in display order.
+	opts []string
+
+	// onSelect is called with the index and text of the chosen option.
+	onSelect func(index int, text string)
+
+	// label is the text currently displayed on the button, either the
+	// first option or the text of the last selected option.
+	label string
+
+	// o are the configured button options.
+	o *options
+
+	// open indicates whether the overlay option list is currently shown.
+	open bool
+	// highlight is the index of the currently highlighted option while
+	// open is true.
+	highlight int
+	// openUpward indicates the overlay was drawn above the button on the
+	// last Draw call, as decided by overlayPlacement.
+	openUpward bool
+	// faceArea is the button's own face area on the canvas as of the last
+	// Draw call, used by Mouse to tell a click on the face from one in the
+	// overlay.
+	faceArea image.Rectangle
+	// overlayArea is the overlay's area on the canvas as of the last Draw
+	// call while open, used by Mouse to translate a click into an option
+	// index. It is the zero Rectangle while closed.
+	overlayArea image.Rectangle
+	// quickSelect accumulates the runes typed while the overlay is open,
+	// used to jump to the first option with a matching prefix.
+	quickSelect string
+
+	mu sync.Mutex
+}
+
+// NewDropDown returns a new DropDown that presents the provided options in
+// an overlay list and calls onSelect when one of them is chosen.
+func NewDropDown(options []string, onSelect func(index int, text string), opts ...Option) (*DropDown, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("the options list must not be empty")
+	}
+	for _, o := range options {
+		if err := validateText(o); err != nil {
+			return nil, err
+		}
+	}
+	if onSelect == nil {
+		return nil, fmt.Errorf("the onSelect function must not be nil")
+	}
+
+	maxWidth := 0
+	for _, o := range options {
+		if w := runewidth.StringWidth(o); w > maxWidth {
+			maxWidth = w
+		}
+	}
+
+	o := newOptions(maxWidth)
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	return &DropDown{
+		opts:     options,
+		onSelect: onSelect,
+		label:    options[0],
+		o:        o,
+	}, nil
+}
+
+// visibleOptions returns the number of options shown in the overlay at
+// once.
+func (d *DropDown) visibleOptions() int {
+	if n := len(d.opts); n < d.o.maxVisibleOptions {
+		return n
+	}
+	return d.o.maxVisibleOptions
+}
+
+// toggle opens or closes the overlay list.
+func (d *DropDown) toggle() {
+	if d.open {
+		d.close()
+		return
+	}
+	d.open = true
+	d.quickSelect = ""
+	for i, o := range d.opts {
+		if o == d.label {
+			d.highlight = i
+			return
+		}
+	}
+	d.highlight = 0
+}
+
+// close hides the overlay list without changing the selection.
+func (d *DropDown) close() {
+	d.open = false
+	d.quickSelect = ""
+}
+
+// commit selects the currently highlighted option and closes the overlay.
+func (d *DropDown) commit() {
+	idx := d.highlight
+	d.label = d.opts[idx]
+	d.close()
+	d.onSelect(idx, d.label)
+}
+
+// jumpToPrefix moves the highlight to the first option starting with the
+// accumulated quick-select text, if any.
+func (d *DropDown) jumpToPrefix() {
+	if d.quickSelect == "" {
+		return
+	}
+	prefix := strings.ToLower(d.quickSelect)
+	for i, o := range d.opts {
+		if strings.HasPrefix(strings.ToLower(o), prefix) {
+			d.highlight = i
+			return
+		}
+	}
+}
+
+// overlayPlacement computes, in canvas-local coordinates, where a
+// DropDown's button face and option overlay should be drawn within a
+// canvas of canvasSize, given the fixed face height and how many option
+// rows are visible.
+//
+// The widget has no way to learn its own position within the terminal (a
+// canvas is always local-origin, so comparing against the face's own
+// coordinates can never reveal whether the real terminal has room above
+// it), so opening upward is only ever attempted when preferUpward was
+// requested via the OpenUpward option, and even then only takes effect
+// when the canvas actually has rows to spare beyond the fixed face
+// height; the face then moves to the bottom of the canvas and the
+// overlay fills the spare rows above it. Otherwise the face stays at the
+// top of the canvas and the overlay is clipped to however many rows fit
+// below it.
+func overlayPlacement(canvasSize image.Point, width, height, visible int, preferUpward bool) (face, overlay image.Rectangle, openUpward bool) {
+	extra := canvasSize.Y - height
+	if extra < 0 {
+		extra = 0
+	}
+	rows := visible
+	if rows > extra {
+		rows = extra
+	}
+
+	if preferUpward && extra > 0 {
+		face = image.Rect(0, canvasSize.Y-height, width, canvasSize.Y)
+		overlay = image.Rect(0, face.Min.Y-rows, width, face.Min.Y)
+		return face, overlay, true
+	}
+	face = image.Rect(0, 0, width, height)
+	overlay = image.Rect(0, height, width, height+rows)
+	return face, overlay, false
+}
+
+// overlayScrollFirst returns the index of the first option shown in the
+// overlay so that a window of visible consecutive options out of total
+// always contains highlight.
+func overlayScrollFirst(highlight, visible, total int) int {
+	first := 0
+	if highlight >= visible {
+		first = highlight - visible + 1
+	}
+	if max := total - visible; first > max {
+		first = max
+	}
+	if first < 0 {
+		first = 0
+	}
+	return first
+}
+
+// Draw draws the DropDown widget, its button face and, when open, the
+// overlay option list.
+// Implements widgetapi.Widget.Draw.
+func (d *DropDown) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	area := cvs.Area()
+	visible := 0
+	preferUpward := false
+	if d.open {
+		visible = d.visibleOptions()
+		preferUpward = d.o.preferUpward
+	}
+	face, overlay, openUpward := overlayPlacement(cvs.Size(), area.Dx(), d.o.height, visible, preferUpward)
+	face = face.Add(area.Min)
+	overlay = overlay.Add(area.Min)
+	d.openUpward = openUpward
+	d.faceArea = face
+	d.overlayArea = overlay
+
+	textAreaHeight := d.o.height - 1 // Bottom row is reserved for the shadow.
+	textArea := image.Rect(face.Min.X, face.Min.Y, face.Max.X, face.Min.Y+textAreaHeight)
+	if err := draw.Rectangle(cvs, textArea, draw.RectCellOpts(cell.BgColor(d.o.fillColor))); err != nil {
+		return err
+	}
+
+	shadowArea := image.Rect(face.Min.X+1, face.Min.Y+textAreaHeight, face.Max.X, face.Max.Y)
+	if err := draw.Rectangle(cvs, shadowArea, draw.RectCellOpts(cell.BgColor(d.o.shadowColor))); err != nil {
+		return err
+	}
+
+	start := image.Point{face.Min.X, face.Min.Y + textAreaHeight/2}
+	if err := draw.Text(
+		cvs, d.label, start,
+		draw.TextMaxX(face.Max.X),
+		draw.TextCellOpts(cell.FgColor(d.o.textColor), cell.BgColor(d.o.fillColor)),
+		draw.TextAlignHorizontal(align.HorizontalCenter),
+	); err != nil {
+		return err
+	}
+
+	if !d.open {
+		return nil
+	}
+	return d.drawOverlay(cvs, overlay)
+}
+
+// drawOverlay draws the scrollable list of options into overlay.
+func (d *DropDown) drawOverlay(cvs *canvas.Canvas, overlay image.Rectangle) error {
+	visible := d.visibleOptions()
+	first := overlayScrollFirst(d.highlight, visible, len(d.opts))
+
+	if err := draw.Rectangle(cvs, overlay, draw.RectCellOpts(cell.BgColor(d.o.listFillColor))); err != nil {
+		return err
+	}
+
+	for row := 0; row < overlay.Dy(); row++ {
+		idx := first + row
+		if idx >= len(d.opts) {
+			break
+		}
+		opt := d.opts[idx]
+
+		bg := d.o.listFillColor
+		if idx == d.highlight {
+			bg = d.o.listSelectedColor
+		}
+		y := overlay.Min.Y + row
+		if err := draw.Rectangle(
+			cvs, image.Rect(overlay.Min.X, y, overlay.Max.X, y+1),
+			draw.RectCellOpts(cell.BgColor(bg)),
+		); err != nil {
+			return err
+		}
+
+		cellOpts := []cell.Option{cell.FgColor(d.o.textColor), cell.BgColor(bg)}
+		prefixLen := 0
+		if d.quickSelect != "" && strings.HasPrefix(strings.ToLower(opt), strings.ToLower(d.quickSelect)) {
+			prefixLen = len(d.quickSelect)
+		}
+		if prefixLen > 0 {
+			if err := draw.Text(
+				cvs, opt[:prefixLen], image.Point{overlay.Min.X, y},
+				draw.TextMaxX(overlay.Max.X),
+				draw.TextCellOpts(cell.FgColor(d.o.prefixTextColor), cell.BgColor(bg)),
+			); err != nil {
+				return err
+			}
+			if err := draw.Text(
+				cvs, opt[prefixLen:], image.Point{overlay.Min.X + prefixLen, y},
+				draw.TextMaxX(overlay.Max.X),
+				draw.TextCellOpts(cellOpts...),
+			); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := draw.Text(
+			cvs, opt, image.Point{overlay.Min.X, y},
+			draw.TextMaxX(overlay.Max.X),
+			draw.TextCellOpts(cellOpts...),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (d *DropDown) Keyboard(k *terminalapi.Keyboard, meta *widgetapi.EventMeta) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.open {
+		if k.Key == d.o.key {
+			d.toggle()
+		}
+		return nil
+	}
+
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		if d.highlight > 0 {
+			d.highlight--
+		}
+		d.quickSelect = ""
+	case keyboard.KeyArrowDown:
+		if d.highlight < len(d.opts)-1 {
+			d.highlight++
+		}
+		d.quickSelect = ""
+	case keyboard.KeyEnter:
+		d.commit()
+	case keyboard.KeyEsc:
+		d.close()
+	default:
+		if r := rune(k.Key); unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			d.quickSelect += string(r)
+			d.jumpToPrefix()
+		}
+	}
+	return nil
+}
+
+// overlayIndexAt returns the option index a click at absolute y lands on,
+// given the absolute Y coordinate where the overlay's first visible row
+// starts (overlayTop), the index of the option currently scrolled to that
+// first row (first) and the total option count. The caller is expected to
+// have already confirmed the click falls within the overlay's area; ok is
+// false only when the resulting index falls outside the range [0, total).
+func overlayIndexAt(y, overlayTop, first, total int) (idx int, ok bool) {
+	idx = first + (y - overlayTop)
+	if idx < 0 || idx >= total {
+		return 0, false
+	}
+	return idx, true
+}
+
+// Mouse processes mouse events, toggling the overlay on a click on the
+// button and selecting an option on a click within the overlay.
+// Implements widgetapi.Widget.Mouse.
+func (d *DropDown) Mouse(m *terminalapi.Mouse, meta *widgetapi.EventMeta) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if m.Button != mouse.ButtonLeft {
+		return nil
+	}
+	if !d.open {
+		d.toggle()
+		return nil
+	}
+	if m.Position.In(d.faceArea) {
+		// The click landed back on the button's own face, not the
+		// overlay; toggle it closed rather than picking an option.
+		d.toggle()
+		return nil
+	}
+	if !m.Position.In(d.overlayArea) {
+		d.close()
+		return nil
+	}
+
+	visible := d.visibleOptions()
+	first := overlayScrollFirst(d.highlight, visible, len(d.opts))
+	idx, ok := overlayIndexAt(m.Position.Y, d.overlayArea.Min.Y, first, len(d.opts))
+	if !ok {
+		d.close()
+		return nil
+	}
+	d.highlight = idx
+	d.commit()
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (d *DropDown) Options() widgetapi.Options {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	maxHeight := d.o.height + d.visibleOptions()
+	return widgetapi.Options{
+		MinimumSize:  image.Point{d.o.width, d.o.height},
+		MaximumSize:  image.Point{d.o.width, maxHeight},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
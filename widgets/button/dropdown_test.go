@@ -0,0 +1,225 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+import (
+	"image"
+	"testing"
+)
+
+func TestOverlayPlacement(t *testing.T) {
+	tests := []struct {
+		desc           string
+		canvasSize     image.Point
+		width          int
+		height         int
+		visible        int
+		preferUpward   bool
+		wantFace       image.Rectangle
+		wantOverlay    image.Rectangle
+		wantOpenUpward bool
+	}{
+		{
+			desc:        "closed: face at the top, no overlay",
+			canvasSize:  image.Point{10, 2},
+			width:       10,
+			height:      2,
+			visible:     0,
+			wantFace:    image.Rect(0, 0, 10, 2),
+			wantOverlay: image.Rect(0, 2, 10, 2),
+		},
+		{
+			desc:        "open, downward, full room granted: exactly matches the real Draw call site",
+			canvasSize:  image.Point{10, 5}, // height(2) + visible(3), as Options().MaximumSize requests.
+			width:       10,
+			height:      2,
+			visible:     3,
+			wantFace:    image.Rect(0, 0, 10, 2),
+			wantOverlay: image.Rect(0, 2, 10, 5),
+		},
+		{
+			desc:        "open, downward, container granted less than requested: clips instead of going blank",
+			canvasSize:  image.Point{10, 3},
+			width:       10,
+			height:      2,
+			visible:     3,
+			wantFace:    image.Rect(0, 0, 10, 2),
+			wantOverlay: image.Rect(0, 2, 10, 3),
+		},
+		{
+			desc:        "open, downward, no extra room granted at all: overlay is empty, not a zero-row box drawn over the face",
+			canvasSize:  image.Point{10, 2},
+			width:       10,
+			height:      2,
+			visible:     3,
+			wantFace:    image.Rect(0, 0, 10, 2),
+			wantOverlay: image.Rect(0, 2, 10, 2),
+		},
+		{
+			desc:           "open, upward preferred and room granted: face moves to the bottom, overlay fills the rows above it",
+			canvasSize:     image.Point{10, 5},
+			width:          10,
+			height:         2,
+			visible:        3,
+			preferUpward:   true,
+			wantFace:       image.Rect(0, 3, 10, 5),
+			wantOverlay:    image.Rect(0, 0, 10, 3),
+			wantOpenUpward: true,
+		},
+		{
+			desc:         "open, upward preferred but no extra room granted: falls back to downward rather than going blank",
+			canvasSize:   image.Point{10, 2},
+			width:        10,
+			height:       2,
+			visible:      3,
+			preferUpward: true,
+			wantFace:     image.Rect(0, 0, 10, 2),
+			wantOverlay:  image.Rect(0, 2, 10, 2),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotFace, gotOverlay, gotOpenUpward := overlayPlacement(tc.canvasSize, tc.width, tc.height, tc.visible, tc.preferUpward)
+			if gotFace != tc.wantFace {
+				t.Errorf("overlayPlacement(...) => face %v, want %v", gotFace, tc.wantFace)
+			}
+			if gotOverlay != tc.wantOverlay {
+				t.Errorf("overlayPlacement(...) => overlay %v, want %v", gotOverlay, tc.wantOverlay)
+			}
+			if gotOpenUpward != tc.wantOpenUpward {
+				t.Errorf("overlayPlacement(...) => openUpward %v, want %v", gotOpenUpward, tc.wantOpenUpward)
+			}
+		})
+	}
+}
+
+// TestOverlayPlacementThroughOptions drives overlayPlacement with exactly
+// the canvasSize a real container would hand Draw after granting the
+// MaximumSize a DropDown's Options() call requests, rather than a
+// synthetic shape Draw itself could never produce.
+func TestOverlayPlacementThroughOptions(t *testing.T) {
+	dd, err := NewDropDown([]string{"one", "two", "three", "four"}, func(int, string) {}, Width(10), Height(2), MaxVisibleOptions(3))
+	if err != nil {
+		t.Fatalf("NewDropDown() => unexpected error: %v", err)
+	}
+	dd.open = true
+
+	opts := dd.Options()
+	visible := dd.visibleOptions()
+	canvasSize := opts.MaximumSize // What a container grants when it has the room the widget asked for.
+
+	face, overlay, openUpward := overlayPlacement(canvasSize, opts.MaximumSize.X, dd.o.height, visible, dd.o.preferUpward)
+	if openUpward {
+		t.Error("overlayPlacement(...) => openUpward true, want false (OpenUpward was not requested)")
+	}
+	if got, want := overlay.Dy(), visible; got != want {
+		t.Errorf("overlay.Dy() => %d, want %d (the overlay must draw every visible option, not a zero-height box)", got, want)
+	}
+	if overlay.Min.Y != face.Max.Y {
+		t.Errorf("overlay.Min.Y => %d, want %d (directly beneath the button face)", overlay.Min.Y, face.Max.Y)
+	}
+}
+
+func TestOverlayScrollFirst(t *testing.T) {
+	tests := []struct {
+		desc      string
+		highlight int
+		visible   int
+		total     int
+		want      int
+	}{
+		{
+			desc:      "highlight within the first window",
+			highlight: 1,
+			visible:   3,
+			total:     10,
+			want:      0,
+		},
+		{
+			desc:      "highlight past the window scrolls it forward",
+			highlight: 5,
+			visible:   3,
+			total:     10,
+			want:      3,
+		},
+		{
+			desc:      "window never scrolls past the last option",
+			highlight: 9,
+			visible:   3,
+			total:     10,
+			want:      7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := overlayScrollFirst(tc.highlight, tc.visible, tc.total); got != tc.want {
+				t.Errorf("overlayScrollFirst(%d, %d, %d) => %d, want %d", tc.highlight, tc.visible, tc.total, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOverlayIndexAt(t *testing.T) {
+	tests := []struct {
+		desc       string
+		y          int
+		overlayTop int
+		first      int
+		total      int
+		wantIdx    int
+		wantOk     bool
+	}{
+		{
+			desc:       "click on the first overlay row selects the first visible option",
+			y:          2,
+			overlayTop: 2,
+			first:      0,
+			total:      5,
+			wantIdx:    0,
+			wantOk:     true,
+		},
+		{
+			desc:       "click on a later overlay row accounts for the scrolled window",
+			y:          4,
+			overlayTop: 2,
+			first:      2,
+			total:      5,
+			wantIdx:    4,
+			wantOk:     true,
+		},
+		{
+			desc:       "resulting index past the last option is rejected",
+			y:          4,
+			overlayTop: 2,
+			first:      4,
+			total:      5,
+			wantOk:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotIdx, gotOk := overlayIndexAt(tc.y, tc.overlayTop, tc.first, tc.total)
+			if gotOk != tc.wantOk {
+				t.Errorf("overlayIndexAt(%d, %d, %d, %d) => ok %v, want %v", tc.y, tc.overlayTop, tc.first, tc.total, gotOk, tc.wantOk)
+			}
+			if gotOk && gotIdx != tc.wantIdx {
+				t.Errorf("overlayIndexAt(%d, %d, %d, %d) => idx %d, want %d", tc.y, tc.overlayTop, tc.first, tc.total, gotIdx, tc.wantIdx)
+			}
+		})
+	}
+}
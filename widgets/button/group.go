@@ -0,0 +1,143 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+// group.go contains the ButtonGroup, which coordinates a set of Buttons as
+// a mutually-exclusive selector (a horizontal radio bar).
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ButtonGroup coordinates a set of Buttons registered to it via the
+// GroupMember option so that at most one of them is ever in the "selected"
+// state, like a group of radio buttons.
+type ButtonGroup struct {
+	mu       sync.Mutex
+	members  []*Button
+	selected *Button
+	onChange func(*Button)
+}
+
+// NewButtonGroup returns a new, empty ButtonGroup. Buttons join the group
+// by passing it to the GroupMember option at construction time.
+func NewButtonGroup() *ButtonGroup {
+	return &ButtonGroup{}
+}
+
+// register adds b to the group. Called by newButton (shared by New and
+// NewFromChunks) when a Button is constructed with the GroupMember option.
+func (g *ButtonGroup) register(b *Button) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.members = append(g.members, b)
+}
+
+// selectButton marks b as the selected member of the group, implicitly
+// clearing the selected state of whichever member was selected before.
+func (g *ButtonGroup) selectButton(b *Button) {
+	g.mu.Lock()
+	g.selected = b
+	onChange := g.onChange
+	g.mu.Unlock()
+
+	if onChange != nil {
+		onChange(b)
+	}
+}
+
+// isSelected reports whether b is the currently selected member of the
+// group.
+func (g *ButtonGroup) isSelected(b *Button) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.selected == b
+}
+
+// Selected returns the currently selected button in the group, or nil if no
+// member has been selected yet.
+func (g *ButtonGroup) Selected() *Button {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.selected
+}
+
+// SelectByLabel selects the member button whose label matches text,
+// visually selecting it and invoking the group's OnChange callback as if
+// the user had pressed it. Returns an error if no member has that label.
+func (g *ButtonGroup) SelectByLabel(text string) error {
+	g.mu.Lock()
+	members := append([]*Button(nil), g.members...)
+	g.mu.Unlock()
+
+	for _, m := range members {
+		if m.text == text {
+			g.selectButton(m)
+			return nil
+		}
+	}
+	return fmt.Errorf("no button in the group has the label %q", text)
+}
+
+// OnChange configures a callback invoked with the newly selected button
+// every time the group's selection changes, either via a press or via
+// SelectByLabel.
+func (g *ButtonGroup) OnChange(cb func(*Button)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.onChange = cb
+}
+
+// adjacent returns the group member offset positions away from the
+// navigation anchor in the registration order, wrapping at the ends, or
+// nil if the group has fewer than two members.
+//
+// The anchor is the currently selected member, if the group has one,
+// rather than b itself. Only the focused button's Keyboard is ever
+// invoked, so anchoring on b would make repeated arrow-key presses from
+// that same focused button always recompute the same one-step neighbor;
+// anchoring on the selection instead lets repeated presses walk every
+// member in turn (A->B->C->D). Before any member has been selected, b is
+// used as the anchor.
+func (g *ButtonGroup) adjacent(b *Button, offset int) *Button {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.members) < 2 {
+		return nil
+	}
+	anchor := b
+	if g.selected != nil {
+		anchor = g.selected
+	}
+	idx := -1
+	for i, m := range g.members {
+		if m == anchor {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+	n := len(g.members)
+	next := ((idx+offset)%n + n) % n
+	return g.members[next]
+}
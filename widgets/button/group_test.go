@@ -0,0 +1,110 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+import "testing"
+
+func newTestGroupMember(t *testing.T, g *ButtonGroup, label string) *Button {
+	t.Helper()
+	b, err := New(label, func() error { return nil }, GroupMember(g))
+	if err != nil {
+		t.Fatalf("New(%q) => unexpected error: %v", label, err)
+	}
+	return b
+}
+
+func TestButtonGroupSelection(t *testing.T) {
+	g := NewButtonGroup()
+	a := newTestGroupMember(t, g, "A")
+	b := newTestGroupMember(t, g, "B")
+
+	var changed *Button
+	g.OnChange(func(b *Button) { changed = b })
+
+	g.selectButton(a)
+	if !g.isSelected(a) || g.isSelected(b) {
+		t.Errorf("after selecting a, isSelected(a) = %v, isSelected(b) = %v, want true, false", g.isSelected(a), g.isSelected(b))
+	}
+	if changed != a {
+		t.Errorf("OnChange callback got %v, want %v", changed, a)
+	}
+
+	g.selectButton(b)
+	if g.isSelected(a) || !g.isSelected(b) {
+		t.Errorf("after selecting b, isSelected(a) = %v, isSelected(b) = %v, want false, true", g.isSelected(a), g.isSelected(b))
+	}
+}
+
+func TestButtonGroupSelectByLabel(t *testing.T) {
+	g := NewButtonGroup()
+	newTestGroupMember(t, g, "A")
+	b := newTestGroupMember(t, g, "B")
+
+	if err := g.SelectByLabel("B"); err != nil {
+		t.Fatalf("SelectByLabel(\"B\") => unexpected error: %v", err)
+	}
+	if g.Selected() != b {
+		t.Errorf("Selected() => %v, want %v", g.Selected(), b)
+	}
+
+	if err := g.SelectByLabel("nope"); err == nil {
+		t.Error("SelectByLabel(\"nope\") => nil error, want an error for an unknown label")
+	}
+}
+
+func TestButtonGroupAdjacent(t *testing.T) {
+	g := NewButtonGroup()
+	a := newTestGroupMember(t, g, "A")
+	b := newTestGroupMember(t, g, "B")
+	c := newTestGroupMember(t, g, "C")
+	d := newTestGroupMember(t, g, "D")
+
+	t.Run("before any selection, anchors on the focused button", func(t *testing.T) {
+		if got := g.adjacent(a, 1); got != b {
+			t.Errorf("adjacent(a, 1) => %v, want %v", got, b)
+		}
+		if got := g.adjacent(a, -1); got != d {
+			t.Errorf("adjacent(a, -1) => %v, want %v", got, d)
+		}
+	})
+
+	t.Run("repeated presses from the same focused button walk every member", func(t *testing.T) {
+		// This mirrors Keyboard always calling adjacent on whichever
+		// widget currently holds container focus (b, here), which never
+		// changes just because the group's selection does.
+		if got := g.adjacent(b, 1); got != c {
+			t.Fatalf("adjacent(b, 1) => %v, want %v", got, c)
+		}
+		g.selectButton(c)
+
+		if got := g.adjacent(b, 1); got != d {
+			t.Fatalf("adjacent(b, 1) after selecting c => %v, want %v", got, d)
+		}
+		g.selectButton(d)
+
+		if got := g.adjacent(b, 1); got != a {
+			t.Fatalf("adjacent(b, 1) after selecting d => %v, want %v", got, a)
+		}
+	})
+}
+
+func TestButtonGroupAdjacentSingleMember(t *testing.T) {
+	g := NewButtonGroup()
+	a := newTestGroupMember(t, g, "A")
+
+	if got := g.adjacent(a, 1); got != nil {
+		t.Errorf("adjacent(a, 1) on a single-member group => %v, want nil", got)
+	}
+}
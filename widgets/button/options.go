@@ -18,6 +18,7 @@ package button
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/keyboard"
@@ -45,6 +46,57 @@ type options struct {
 	height      int
 	width       int
 	key         keyboard.Key
+
+	// prefixTextColor is the color of the quick-select prefix highlighted
+	// within an option of a DropDown's overlay list.
+	prefixTextColor cell.Color
+	// listFillColor is the background color of a DropDown's overlay list.
+	listFillColor cell.Color
+	// listSelectedColor is the background color of the highlighted option
+	// in a DropDown's overlay list.
+	listSelectedColor cell.Color
+	// maxVisibleOptions is the maximum number of options a DropDown shows
+	// in its overlay list before it starts scrolling.
+	maxVisibleOptions int
+	// preferUpward requests that a DropDown's overlay render above its
+	// button face instead of below it.
+	preferUpward bool
+
+	// selectedFillColor is the fill color of a Button while it is the
+	// selected member of a ButtonGroup.
+	selectedFillColor cell.Color
+	// selectedTextColor is the text color of a Button while it is the
+	// selected member of a ButtonGroup.
+	selectedTextColor cell.Color
+	// group is the ButtonGroup this Button is a member of, or nil.
+	group *ButtonGroup
+
+	// hoverFillColor is the fill color of a Button while the mouse cursor
+	// is over it.
+	hoverFillColor cell.Color
+	// hoverTextColor is the text color of a Button while the mouse cursor
+	// is over it.
+	hoverTextColor cell.Color
+	// hoverShadowColor is the shadow color of a Button while the mouse
+	// cursor is over it.
+	hoverShadowColor cell.Color
+
+	// pressedFillColor is the fill color of a Button while it is pressed.
+	pressedFillColor cell.Color
+	// pressedTextColor is the text color of a Button while it is pressed.
+	pressedTextColor cell.Color
+	// pressedDuration is how long a keyboard-triggered press keeps
+	// showing the pressed colors for.
+	pressedDuration time.Duration
+
+	// disabledFillColor is the fill color of a Button while it is
+	// disabled.
+	disabledFillColor cell.Color
+	// disabledTextColor is the text color of a Button while it is
+	// disabled.
+	disabledTextColor cell.Color
+	// disabled indicates the button rejects callback activation.
+	disabled bool
 }
 
 // validate validates the provided options.
@@ -58,15 +110,18 @@ func (o *options) validate() error {
 	return nil
 }
 
-// newOptions returns options with the default values set.
+// newOptions returns options with the values of CurrentStyles set,
+// falling back to an automatic width based on textWidth when the style
+// doesn't request a fixed one.
 func newOptions(textWidth int) *options {
-	return &options{
-		fillColor:   DefaultFillColor,
-		textColor:   DefaultTextColor,
-		shadowColor: DefaultShadowColor,
-		height:      DefaultHeight,
-		width:       textWidth + 2, // One empty cell on each side of the text.
+	s := CurrentStyles()
+	if s.Width <= 0 {
+		s.Width = textWidth + 2 // One empty cell on each side of the text.
 	}
+
+	o := &options{}
+	applyStyles(o, s)
+	return o
 }
 
 // DefaultFillColor is the default for the FillColor option.
@@ -133,3 +188,206 @@ func Key(k keyboard.Key) Option {
 		opts.key = k
 	})
 }
+
+// DefaultPrefixTextColor is the default for the PrefixTextColor option.
+const DefaultPrefixTextColor = cell.ColorYellow
+
+// PrefixTextColor sets the color used to highlight the quick-select prefix
+// that matches the runes typed so far within an option of a DropDown's
+// overlay list.
+// Defaults to DefaultPrefixTextColor.
+func PrefixTextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.prefixTextColor = c
+	})
+}
+
+// DefaultListFillColor is the default for the ListFillColor option.
+const DefaultListFillColor = cell.ColorCyan
+
+// ListFillColor sets the background color of a DropDown's overlay option
+// list.
+// Defaults to DefaultListFillColor.
+func ListFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.listFillColor = c
+	})
+}
+
+// DefaultListSelectedColor is the default for the ListSelectedColor option.
+const DefaultListSelectedColor = cell.ColorBlue
+
+// ListSelectedColor sets the background color of the currently highlighted
+// option in a DropDown's overlay list.
+// Defaults to DefaultListSelectedColor.
+func ListSelectedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.listSelectedColor = c
+	})
+}
+
+// DefaultMaxVisibleOptions is the default for the MaxVisibleOptions option.
+const DefaultMaxVisibleOptions = 5
+
+// MaxVisibleOptions sets the maximum number of options a DropDown shows in
+// its overlay list at once. When there are more options than this, the list
+// scrolls to keep the highlighted option visible.
+// Defaults to DefaultMaxVisibleOptions.
+func MaxVisibleOptions(max int) Option {
+	return option(func(opts *options) {
+		opts.maxVisibleOptions = max
+	})
+}
+
+// OpenUpward requests that a DropDown's overlay render above its button
+// face instead of below it. The widget has no way to learn its own
+// position within the terminal, so this is a hint the application sets
+// itself when it knows the DropDown sits near the bottom of the screen;
+// it only takes effect when the canvas actually has room above the face
+// to draw into, and otherwise falls back to opening downward.
+// Defaults to false.
+func OpenUpward(upward bool) Option {
+	return option(func(opts *options) {
+		opts.preferUpward = upward
+	})
+}
+
+// DefaultSelectedFillColor is the default for the SelectedFillColor option.
+const DefaultSelectedFillColor = cell.ColorWhite
+
+// SelectedFillColor sets the fill color of a Button while it is the
+// selected member of its ButtonGroup.
+// Defaults to DefaultSelectedFillColor.
+func SelectedFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectedFillColor = c
+	})
+}
+
+// DefaultSelectedTextColor is the default for the SelectedTextColor option.
+const DefaultSelectedTextColor = cell.ColorBlack
+
+// SelectedTextColor sets the text color of a Button while it is the
+// selected member of its ButtonGroup.
+// Defaults to DefaultSelectedTextColor.
+func SelectedTextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectedTextColor = c
+	})
+}
+
+// GroupMember registers the button as a member of the provided ButtonGroup
+// at construction time, making it participate in the group's
+// mutually-exclusive selection and arrow-key navigation.
+func GroupMember(g *ButtonGroup) Option {
+	return option(func(opts *options) {
+		opts.group = g
+	})
+}
+
+// DefaultHoverFillColor is the default for the HoverFillColor option.
+const DefaultHoverFillColor = cell.ColorCyan
+
+// HoverFillColor sets the fill color of the button while the mouse cursor
+// is over it.
+// Defaults to DefaultHoverFillColor.
+func HoverFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.hoverFillColor = c
+	})
+}
+
+// DefaultHoverTextColor is the default for the HoverTextColor option.
+const DefaultHoverTextColor = cell.ColorBlack
+
+// HoverTextColor sets the text color of the button while the mouse cursor
+// is over it.
+// Defaults to DefaultHoverTextColor.
+func HoverTextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.hoverTextColor = c
+	})
+}
+
+// DefaultHoverShadowColor is the default for the HoverShadowColor option.
+const DefaultHoverShadowColor = cell.Color(250)
+
+// HoverShadowColor sets the shadow color of the button while the mouse
+// cursor is over it.
+// Defaults to DefaultHoverShadowColor.
+func HoverShadowColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.hoverShadowColor = c
+	})
+}
+
+// DefaultPressedFillColor is the default for the PressedFillColor option.
+const DefaultPressedFillColor = cell.ColorWhite
+
+// PressedFillColor sets the fill color of the button while it is pressed,
+// i.e. while the mouse button is held down over it or, for a configurable
+// duration, after its keyboard Key was pressed.
+// Defaults to DefaultPressedFillColor.
+func PressedFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.pressedFillColor = c
+	})
+}
+
+// DefaultPressedTextColor is the default for the PressedTextColor option.
+const DefaultPressedTextColor = cell.ColorBlack
+
+// PressedTextColor sets the text color of the button while it is pressed.
+// Defaults to DefaultPressedTextColor.
+func PressedTextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.pressedTextColor = c
+	})
+}
+
+// DefaultPressedDuration is the default for the PressedDuration option.
+const DefaultPressedDuration = 150 * time.Millisecond
+
+// PressedDuration sets how long the button keeps showing its pressed
+// colors after being activated with its keyboard Key (mouse presses show
+// the pressed colors for as long as the mouse button is held).
+// Defaults to DefaultPressedDuration.
+func PressedDuration(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.pressedDuration = d
+	})
+}
+
+// DefaultDisabledFillColor is the default for the DisabledFillColor option.
+const DefaultDisabledFillColor = cell.ColorGray
+
+// DisabledFillColor sets the fill color of the button while it is
+// disabled.
+// Defaults to DefaultDisabledFillColor.
+func DisabledFillColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.disabledFillColor = c
+	})
+}
+
+// DefaultDisabledTextColor is the default for the DisabledTextColor option.
+const DefaultDisabledTextColor = cell.ColorBlack
+
+// DisabledTextColor sets the text color of the button while it is
+// disabled.
+// Defaults to DefaultDisabledTextColor.
+func DisabledTextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.disabledTextColor = c
+	})
+}
+
+// Disabled sets the initial disabled state of the button. A disabled
+// button ignores keyboard and mouse activation and renders with its
+// DisabledFillColor and DisabledTextColor.
+// Defaults to false.
+func Disabled(disabled bool) Option {
+	return option(func(opts *options) {
+		opts.disabled = disabled
+	})
+}
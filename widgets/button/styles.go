@@ -0,0 +1,247 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+// styles.go contains the Styles theme bundle, letting an application set
+// every button default with a single call instead of repeating individual
+// options at each construction site.
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Styles bundles together all of the colors, dimensions and timings that
+// would otherwise have to be set one Option at a time. Pass it to
+// SetDefaultStyles to theme every Button constructed afterwards, or to the
+// Style option to theme a single one.
+type Styles struct {
+	FillColor   cell.Color
+	TextColor   cell.Color
+	ShadowColor cell.Color
+	Height      int
+	// Width is the fixed button width. Zero or negative means the width
+	// is derived automatically from the label, as it is by default.
+	Width int
+	Key   keyboard.Key
+
+	PrefixTextColor   cell.Color
+	ListFillColor     cell.Color
+	ListSelectedColor cell.Color
+	MaxVisibleOptions int
+
+	SelectedFillColor cell.Color
+	SelectedTextColor cell.Color
+
+	HoverFillColor   cell.Color
+	HoverTextColor   cell.Color
+	HoverShadowColor cell.Color
+
+	PressedFillColor cell.Color
+	PressedTextColor cell.Color
+	PressedDuration  time.Duration
+
+	DisabledFillColor cell.Color
+	DisabledTextColor cell.Color
+}
+
+// defaultStylesMu protects defaultStyles from concurrent construction of
+// buttons (which reads it) racing a theme switch (which writes it). The
+// backing variable is unexported so that guard can't be bypassed by a
+// direct write from outside the package; use SetDefaultStyles and
+// CurrentStyles instead.
+var defaultStylesMu sync.Mutex
+
+// defaultStyles is the Styles bundle new Buttons draw their defaults from
+// unless overridden by an individual Option. Change it with
+// SetDefaultStyles to theme every button constructed afterwards, or read
+// it with CurrentStyles.
+var defaultStyles = Styles{
+	FillColor:   DefaultFillColor,
+	TextColor:   DefaultTextColor,
+	ShadowColor: DefaultShadowColor,
+	Height:      DefaultHeight,
+	Key:         DefaultKey,
+
+	PrefixTextColor:   DefaultPrefixTextColor,
+	ListFillColor:     DefaultListFillColor,
+	ListSelectedColor: DefaultListSelectedColor,
+	MaxVisibleOptions: DefaultMaxVisibleOptions,
+
+	SelectedFillColor: DefaultSelectedFillColor,
+	SelectedTextColor: DefaultSelectedTextColor,
+
+	HoverFillColor:   DefaultHoverFillColor,
+	HoverTextColor:   DefaultHoverTextColor,
+	HoverShadowColor: DefaultHoverShadowColor,
+
+	PressedFillColor: DefaultPressedFillColor,
+	PressedTextColor: DefaultPressedTextColor,
+	PressedDuration:  DefaultPressedDuration,
+
+	DisabledFillColor: DefaultDisabledFillColor,
+	DisabledTextColor: DefaultDisabledTextColor,
+}
+
+// SetDefaultStyles merges s onto the Styles bundle currently in effect,
+// theming every Button constructed afterwards that doesn't override a
+// field with its own Option. A zero-valued field in s (e.g. a Styles
+// literal that only sets FillColor) keeps the previous default instead of
+// being applied literally, the same convention Style uses for a single
+// button, so theming one or two fields can never leave the rest, or every
+// button constructed afterwards, with an invalid zero Height or Width.
+func SetDefaultStyles(s Styles) {
+	defaultStylesMu.Lock()
+	defer defaultStylesMu.Unlock()
+
+	defaultStyles = mergeStyles(s, defaultStyles)
+}
+
+// CurrentStyles returns the Styles bundle currently in effect, i.e. the
+// built-in defaults with every prior SetDefaultStyles call merged on top.
+func CurrentStyles() Styles {
+	defaultStylesMu.Lock()
+	defer defaultStylesMu.Unlock()
+
+	return defaultStyles
+}
+
+// applyStyles copies every field of s onto opts.
+func applyStyles(opts *options, s Styles) {
+	opts.fillColor = s.FillColor
+	opts.textColor = s.TextColor
+	opts.shadowColor = s.ShadowColor
+	opts.height = s.Height
+	opts.width = s.Width
+	opts.key = s.Key
+
+	opts.prefixTextColor = s.PrefixTextColor
+	opts.listFillColor = s.ListFillColor
+	opts.listSelectedColor = s.ListSelectedColor
+	opts.maxVisibleOptions = s.MaxVisibleOptions
+
+	opts.selectedFillColor = s.SelectedFillColor
+	opts.selectedTextColor = s.SelectedTextColor
+
+	opts.hoverFillColor = s.HoverFillColor
+	opts.hoverTextColor = s.HoverTextColor
+	opts.hoverShadowColor = s.HoverShadowColor
+
+	opts.pressedFillColor = s.PressedFillColor
+	opts.pressedTextColor = s.PressedTextColor
+	opts.pressedDuration = s.PressedDuration
+
+	opts.disabledFillColor = s.DisabledFillColor
+	opts.disabledTextColor = s.DisabledTextColor
+}
+
+// coalesceColor returns c, or fallback if c is the zero value cell.Color
+// (cell.ColorDefault).
+func coalesceColor(c, fallback cell.Color) cell.Color {
+	if c == 0 {
+		return fallback
+	}
+	return c
+}
+
+// mergeStyles returns a copy of s with every zero-valued field replaced by
+// the corresponding field of fallback. This is the shared convention
+// behind both Style and SetDefaultStyles: overriding a single field (e.g.
+// a Styles literal that only sets FillColor) never zeroes the rest of the
+// theme, the same way a zero Width keeps the automatic label-based width.
+func mergeStyles(s, fallback Styles) Styles {
+	s.FillColor = coalesceColor(s.FillColor, fallback.FillColor)
+	s.TextColor = coalesceColor(s.TextColor, fallback.TextColor)
+	s.ShadowColor = coalesceColor(s.ShadowColor, fallback.ShadowColor)
+	if s.Height <= 0 {
+		s.Height = fallback.Height
+	}
+	if s.Width <= 0 {
+		s.Width = fallback.Width
+	}
+	if s.Key == 0 {
+		s.Key = fallback.Key
+	}
+
+	s.PrefixTextColor = coalesceColor(s.PrefixTextColor, fallback.PrefixTextColor)
+	s.ListFillColor = coalesceColor(s.ListFillColor, fallback.ListFillColor)
+	s.ListSelectedColor = coalesceColor(s.ListSelectedColor, fallback.ListSelectedColor)
+	if s.MaxVisibleOptions <= 0 {
+		s.MaxVisibleOptions = fallback.MaxVisibleOptions
+	}
+
+	s.SelectedFillColor = coalesceColor(s.SelectedFillColor, fallback.SelectedFillColor)
+	s.SelectedTextColor = coalesceColor(s.SelectedTextColor, fallback.SelectedTextColor)
+
+	s.HoverFillColor = coalesceColor(s.HoverFillColor, fallback.HoverFillColor)
+	s.HoverTextColor = coalesceColor(s.HoverTextColor, fallback.HoverTextColor)
+	s.HoverShadowColor = coalesceColor(s.HoverShadowColor, fallback.HoverShadowColor)
+
+	s.PressedFillColor = coalesceColor(s.PressedFillColor, fallback.PressedFillColor)
+	s.PressedTextColor = coalesceColor(s.PressedTextColor, fallback.PressedTextColor)
+	if s.PressedDuration <= 0 {
+		s.PressedDuration = fallback.PressedDuration
+	}
+
+	s.DisabledFillColor = coalesceColor(s.DisabledFillColor, fallback.DisabledFillColor)
+	s.DisabledTextColor = coalesceColor(s.DisabledTextColor, fallback.DisabledTextColor)
+	return s
+}
+
+// optsStyles returns the Styles equivalent of opts' current field values,
+// used as the merge fallback for a partial Style override.
+func optsStyles(opts *options) Styles {
+	return Styles{
+		FillColor:   opts.fillColor,
+		TextColor:   opts.textColor,
+		ShadowColor: opts.shadowColor,
+		Height:      opts.height,
+		Width:       opts.width,
+		Key:         opts.key,
+
+		PrefixTextColor:   opts.prefixTextColor,
+		ListFillColor:     opts.listFillColor,
+		ListSelectedColor: opts.listSelectedColor,
+		MaxVisibleOptions: opts.maxVisibleOptions,
+
+		SelectedFillColor: opts.selectedFillColor,
+		SelectedTextColor: opts.selectedTextColor,
+
+		HoverFillColor:   opts.hoverFillColor,
+		HoverTextColor:   opts.hoverTextColor,
+		HoverShadowColor: opts.hoverShadowColor,
+
+		PressedFillColor: opts.pressedFillColor,
+		PressedTextColor: opts.pressedTextColor,
+		PressedDuration:  opts.pressedDuration,
+
+		DisabledFillColor: opts.disabledFillColor,
+		DisabledTextColor: opts.disabledTextColor,
+	}
+}
+
+// Style applies an entire Styles bundle to a single Button in one call,
+// overriding whatever is currently in effect for that instance. A
+// zero-valued field in s (e.g. a Styles literal that only sets FillColor)
+// keeps whatever the button would otherwise use, the same way a zero
+// Width keeps the automatic label-based width.
+func Style(s Styles) Option {
+	return option(func(opts *options) {
+		applyStyles(opts, mergeStyles(s, optsStyles(opts)))
+	})
+}
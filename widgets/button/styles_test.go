@@ -0,0 +1,100 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package button
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+func TestCoalesceColor(t *testing.T) {
+	tests := []struct {
+		desc     string
+		c        cell.Color
+		fallback cell.Color
+		want     cell.Color
+	}{
+		{
+			desc:     "zero value falls back",
+			c:        cell.Color(0),
+			fallback: cell.ColorRed,
+			want:     cell.ColorRed,
+		},
+		{
+			desc:     "non-zero value is kept as-is",
+			c:        cell.ColorGreen,
+			fallback: cell.ColorRed,
+			want:     cell.ColorGreen,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := coalesceColor(tc.c, tc.fallback); got != tc.want {
+				t.Errorf("coalesceColor(%v, %v) => %v, want %v", tc.c, tc.fallback, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeStyles(t *testing.T) {
+	fallback := Styles{
+		FillColor: cell.ColorRed,
+		TextColor: cell.ColorBlue,
+		Height:    2,
+		Width:     10,
+		Key:       keyboard.KeyEnter,
+	}
+
+	got := mergeStyles(Styles{FillColor: cell.ColorGreen}, fallback)
+
+	want := fallback
+	want.FillColor = cell.ColorGreen
+	if got != want {
+		t.Errorf("mergeStyles(partial override, fallback) => %+v, want %+v", got, want)
+	}
+}
+
+func TestSetDefaultStylesMergesOntoCurrent(t *testing.T) {
+	defaultStylesMu.Lock()
+	saved := defaultStyles
+	defaultStylesMu.Unlock()
+	defer func() {
+		defaultStylesMu.Lock()
+		defaultStyles = saved
+		defaultStylesMu.Unlock()
+	}()
+
+	SetDefaultStyles(Styles{FillColor: cell.ColorGreen})
+
+	got := CurrentStyles()
+	if got.FillColor != cell.ColorGreen {
+		t.Errorf("CurrentStyles().FillColor => %v, want %v", got.FillColor, cell.ColorGreen)
+	}
+	if got.Height != DefaultHeight {
+		t.Errorf("CurrentStyles().Height => %v, want unchanged default %v", got.Height, DefaultHeight)
+	}
+	if got.Width != saved.Width {
+		t.Errorf("CurrentStyles().Width => %v, want unchanged default %v", got.Width, saved.Width)
+	}
+
+	// A partial SetDefaultStyles call must never leave construction of a
+	// new Button with an invalid zero Height or Width.
+	if _, err := New("label", func() error { return nil }); err != nil {
+		t.Errorf("New() after a partial SetDefaultStyles() call => unexpected error: %v", err)
+	}
+}